@@ -1,6 +1,7 @@
 package uinames
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -102,12 +103,15 @@ func Region(region string) Opt {
 
 type Request struct {
 	*http.Request
+	opts   []Opt
+	client *Client
 }
 
 // NewRequest creates an HTTP request based on the included request
 // options.  Requests can be used multiple times by calling the Get()
-// function but there is no provision for rate limiting the request
-// as required by the uinames API.
+// function; retries against the uinames API are handled by the Client
+// that dispatches the request, as is rate limiting when that Client was
+// created with WithRateLimit (see NewClient).
 func NewRequest(opts ...Opt) (*Request, error) {
 	// URL is known to be valid at this point
 	u, _ := url.Parse(URL)
@@ -125,35 +129,54 @@ func NewRequest(opts ...Opt) (*Request, error) {
 	}
 	return &Request{
 		Request: hr,
+		opts:    opts,
 	}, nil
 }
 
+// WithClient binds cl to r so that Get, GetContext and Iter dispatch
+// through it instead of the package-level default Client, e.g.
+//
+//	req, err := NewRequest(Amount(5))
+//	resp, err := req.WithClient(cl).Get()
+//
+// It returns r to allow chaining.
+func (r *Request) WithClient(cl *Client) *Request {
+	r.client = cl
+	return r
+}
+
+func (r *Request) clientOrDefault() *Client {
+	if r.client != nil {
+		return r.client
+	}
+	return defaultClient
+}
+
 // Get returns an array of identities returned from the uinames API as
-// specified by the Request.
+// specified by the Request.  It dispatches through the Client bound
+// with WithClient, or a package-level default Client if none was
+// bound.
 func (r *Request) Get() ([]Response, error) {
-	return r.get(&http.Client{})
+	return r.get(r.clientOrDefault())
 }
 
-func (r *Request) get(cl *http.Client) ([]Response, error) {
-	rl := []Response{}
-	hr, err := (cl).Do(r.Request)
-	if err != nil {
-		return rl, err
-	}
-	if hr.StatusCode != 200 {
-		return rl, unmarshalError(hr)
-	}
-	body, err := getResponseEntityBody(hr)
-	if err != nil {
-		return rl, err
-	}
-	if len(body) > 0 && body[0] == '[' {
-		err = json.Unmarshal(body, &rl)
-		return rl, err
-	}
-	ri := Response{}
-	err = json.Unmarshal(body, &ri)
-	return append(rl, ri), err
+func (r *Request) get(cl *Client) ([]Response, error) {
+	return r.getContext(context.Background(), cl)
+}
+
+// GetContext behaves like Get but honors ctx's deadline or
+// cancellation, both while waiting on the Client's rate limiter and
+// while the HTTP request is in flight.
+func (r *Request) GetContext(ctx context.Context) ([]Response, error) {
+	return r.getContext(ctx, r.clientOrDefault())
+}
+
+// getContext dispatches r through cl and parses the response.  It
+// delegates to HTTPGenerator.generate, the single implementation of
+// the dispatch-and-parse logic shared by Get, GetContext, Iter and the
+// Generator interface.
+func (r *Request) getContext(ctx context.Context, cl *Client) ([]Response, error) {
+	return (&HTTPGenerator{Client: cl}).generate(ctx, r)
 }
 
 // Response contains an individual identity returned from the uinames
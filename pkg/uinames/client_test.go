@@ -146,12 +146,13 @@ func TestGet(t *testing.T) {
 		t.Run(test.Name, func(t *testing.T) {
 			body, err := os.Open("testdata/" + test.BodyFilePath)
 			require.NoError(t, err)
-			cl := &http.Client{
+			cl, err := NewClient(WithHTTPClient(&http.Client{
 				Transport: ResponseRoundTripper{
 					StatusCode: test.StatusCode,
 					Body:       body,
 				},
-			}
+			}))
+			require.NoError(t, err)
 			req, err := NewRequest()
 			require.NoError(t, err)
 			resp, err := req.get(cl)
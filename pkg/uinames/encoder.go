@@ -0,0 +1,191 @@
+package uinames
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"text/template"
+)
+
+// Encoder writes Response values to an underlying io.Writer one at a
+// time.  Callers must call Close when done, to flush any buffered
+// output.
+type Encoder interface {
+	Encode(Response) error
+	Close() error
+}
+
+// Pipe streams every Response from it into enc, closing enc once it is
+// exhausted, enc.Encode returns an error, or ctx is cancelled.
+func Pipe(ctx context.Context, it *ResponseIter, enc Encoder) error {
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			enc.Close()
+			return err
+		}
+		if err := enc.Encode(it.Value()); err != nil {
+			enc.Close()
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		enc.Close()
+		return err
+	}
+	return enc.Close()
+}
+
+// NDJSONEncoder writes each Response as a single line of JSON
+// (newline-delimited JSON, a.k.a. JSON Lines).
+type NDJSONEncoder struct {
+	enc *json.Encoder
+}
+
+// NewNDJSONEncoder creates an NDJSONEncoder writing to w.
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	return &NDJSONEncoder{enc: json.NewEncoder(w)}
+}
+
+// Encode implements Encoder.
+func (e *NDJSONEncoder) Encode(r Response) error {
+	return e.enc.Encode(r)
+}
+
+// Close implements Encoder.  NDJSONEncoder buffers nothing, so Close
+// is always a no-op.
+func (e *NDJSONEncoder) Close() error {
+	return nil
+}
+
+// DefaultCSVColumns is the column order CSVEncoder uses when none is
+// given via WithColumns.
+var DefaultCSVColumns = []string{
+	"name", "surname", "gender", "region", "age", "title", "phone",
+	"birthdate", "email", "password",
+	"credit_card.number", "credit_card.expiration", "credit_card.pin", "credit_card.security",
+	"photo",
+}
+
+// CSVEncoder writes each Response as a row of CSV, with a header row
+// written before the first Response.  CreditCard fields are flattened
+// into credit_card.* columns, Birthdate is formatted as ISO 8601
+// (2006-01-02) and Photo is written as its String() form.
+type CSVEncoder struct {
+	w       *csv.Writer
+	columns []string
+	wrote   bool
+}
+
+// CSVEncoderOpt configures a CSVEncoder.
+type CSVEncoderOpt func(*CSVEncoder)
+
+// WithColumns overrides the column order CSVEncoder writes, which
+// otherwise defaults to DefaultCSVColumns.  Unrecognized column names
+// produce an empty field.
+func WithColumns(columns []string) CSVEncoderOpt {
+	return func(e *CSVEncoder) {
+		e.columns = columns
+	}
+}
+
+// NewCSVEncoder creates a CSVEncoder writing to w.
+func NewCSVEncoder(w io.Writer, opts ...CSVEncoderOpt) *CSVEncoder {
+	e := &CSVEncoder{
+		w:       csv.NewWriter(w),
+		columns: DefaultCSVColumns,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Encode implements Encoder.
+func (e *CSVEncoder) Encode(r Response) error {
+	if !e.wrote {
+		if err := e.w.Write(e.columns); err != nil {
+			return err
+		}
+		e.wrote = true
+	}
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = csvField(r, column)
+	}
+	return e.w.Write(row)
+}
+
+// Close implements Encoder, flushing any buffered rows.
+func (e *CSVEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func csvField(r Response, column string) string {
+	switch column {
+	case "name":
+		return r.Name
+	case "surname":
+		return r.Surname
+	case "gender":
+		return r.Gender
+	case "region":
+		return r.Region
+	case "age":
+		return strconv.Itoa(r.Age)
+	case "title":
+		return r.Title
+	case "phone":
+		return r.Phone
+	case "birthdate":
+		if r.Birthdate.IsZero() {
+			return ""
+		}
+		return r.Birthdate.Format("2006-01-02")
+	case "email":
+		return r.Email
+	case "password":
+		return r.Password
+	case "credit_card.number":
+		return r.CreditCard.Number
+	case "credit_card.expiration":
+		return r.CreditCard.Expiration
+	case "credit_card.pin":
+		return strconv.Itoa(r.CreditCard.Pin)
+	case "credit_card.security":
+		return strconv.Itoa(r.CreditCard.Security)
+	case "photo":
+		if r.Photo == nil {
+			return ""
+		}
+		return r.Photo.String()
+	default:
+		return ""
+	}
+}
+
+// TemplateEncoder writes each Response by executing a text/template
+// against it, e.g. for generating fixtures in a caller-defined format.
+type TemplateEncoder struct {
+	w   io.Writer
+	tpl *template.Template
+}
+
+// NewTemplateEncoder creates a TemplateEncoder that executes tpl
+// against each Response and writes the result to w.
+func NewTemplateEncoder(w io.Writer, tpl *template.Template) *TemplateEncoder {
+	return &TemplateEncoder{w: w, tpl: tpl}
+}
+
+// Encode implements Encoder.
+func (e *TemplateEncoder) Encode(r Response) error {
+	return e.tpl.Execute(e.w, r)
+}
+
+// Close implements Encoder.  TemplateEncoder buffers nothing, so Close
+// is always a no-op.
+func (e *TemplateEncoder) Close() error {
+	return nil
+}
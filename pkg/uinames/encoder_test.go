@@ -0,0 +1,154 @@
+package uinames
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder(&buf)
+	require.NoError(t, enc.Encode(Response{Name: "Ada"}))
+	require.NoError(t, enc.Encode(Response{Name: "Grace"}))
+	require.NoError(t, enc.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "Ada", first["name"])
+
+	var second map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, "Grace", second["name"])
+}
+
+func TestCSVEncoderDefaultColumns(t *testing.T) {
+	photo, err := url.Parse("https://example.com/p.jpg")
+	require.NoError(t, err)
+	r := Response{
+		Name: "Ada", Surname: "Lovelace", Gender: "female", Region: "UK",
+		Age: 36, Title: "Ms", Phone: "555-0100",
+		Birthdate:  time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC),
+		Email:      "ada@example.com",
+		Password:   "secret",
+		CreditCard: CreditCard{Number: "4111", Expiration: "01/30", Pin: 1234, Security: 321},
+		Photo:      photo,
+	}
+
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf)
+	require.NoError(t, enc.Encode(r))
+	require.NoError(t, enc.Close())
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, DefaultCSVColumns, rows[0])
+	assert.Equal(t, []string{
+		"Ada", "Lovelace", "female", "UK", "36", "Ms", "555-0100",
+		"1990-03-15", "ada@example.com", "secret",
+		"4111", "01/30", "1234", "321",
+		"https://example.com/p.jpg",
+	}, rows[1])
+}
+
+func TestCSVEncoderWithColumns(t *testing.T) {
+	r := Response{Name: "Ada", Surname: "Lovelace"}
+
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf, WithColumns([]string{"surname", "name"}))
+	require.NoError(t, enc.Encode(r))
+	require.NoError(t, enc.Close())
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"surname", "name"}, rows[0])
+	assert.Equal(t, []string{"Lovelace", "Ada"}, rows[1])
+}
+
+func TestCSVEncoderEmptyBirthdateAndPhoto(t *testing.T) {
+	r := Response{Name: "Ada"}
+
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf, WithColumns([]string{"birthdate", "photo"}))
+	require.NoError(t, enc.Encode(r))
+	require.NoError(t, enc.Close())
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"", ""}, rows[1])
+}
+
+func TestTemplateEncoder(t *testing.T) {
+	tpl := template.Must(template.New("t").Parse("{{.Name}} {{.Surname}}\n"))
+
+	var buf bytes.Buffer
+	enc := NewTemplateEncoder(&buf, tpl)
+	require.NoError(t, enc.Encode(Response{Name: "Ada", Surname: "Lovelace"}))
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, "Ada Lovelace\n", buf.String())
+}
+
+// trackingEncoder wraps an Encoder and counts Close calls, so Pipe
+// tests can confirm the encoder is always closed.
+type trackingEncoder struct {
+	Encoder
+	closed int
+}
+
+func (e *trackingEncoder) Close() error {
+	e.closed++
+	return e.Encoder.Close()
+}
+
+func TestPipeEncodesAllIdentities(t *testing.T) {
+	rt, _ := amountRoundTripper(t)
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	req, err := NewRequest()
+	require.NoError(t, err)
+	req = req.WithClient(cl)
+
+	var buf bytes.Buffer
+	enc := &trackingEncoder{Encoder: NewNDJSONEncoder(&buf)}
+
+	require.NoError(t, Pipe(context.Background(), req.Iter(context.Background(), 5), enc))
+	assert.Equal(t, 1, enc.closed)
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 5)
+}
+
+func TestPipePropagatesIteratorErrorAndClosesEncoder(t *testing.T) {
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusInternalServerError, `{"error":"boom"}`), nil
+	})
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}), WithRetry(fastRetry(1)))
+	require.NoError(t, err)
+
+	req, err := NewRequest()
+	require.NoError(t, err)
+	req = req.WithClient(cl)
+
+	var buf bytes.Buffer
+	enc := &trackingEncoder{Encoder: NewNDJSONEncoder(&buf)}
+
+	err = Pipe(context.Background(), req.Iter(context.Background(), 5), enc)
+	require.Error(t, err)
+	assert.Equal(t, 1, enc.closed)
+}
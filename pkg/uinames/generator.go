@@ -0,0 +1,333 @@
+package uinames
+
+import (
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	mathrand "math/rand"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Generator produces identities matching the given request options.
+// HTTPGenerator, LocalGenerator and FallbackGenerator are the
+// implementations provided by this package.
+type Generator interface {
+	Generate(opts ...Opt) ([]Response, error)
+	GenerateContext(ctx context.Context, opts ...Opt) ([]Response, error)
+}
+
+// HTTPGenerator is a Generator backed by the uinames.com HTTP API. It
+// is the Generator used internally by Request.Get and Request.GetContext.
+type HTTPGenerator struct {
+	Client *Client
+}
+
+// NewHTTPGenerator creates an HTTPGenerator whose requests are
+// dispatched through a Client configured with opts.
+func NewHTTPGenerator(opts ...ClientOpt) (*HTTPGenerator, error) {
+	cl, err := NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPGenerator{Client: cl}, nil
+}
+
+// Generate implements Generator.
+func (g *HTTPGenerator) Generate(opts ...Opt) ([]Response, error) {
+	return g.GenerateContext(context.Background(), opts...)
+}
+
+// GenerateContext implements Generator.
+func (g *HTTPGenerator) GenerateContext(ctx context.Context, opts ...Opt) ([]Response, error) {
+	req, err := NewRequest(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(ctx, req)
+}
+
+// generate dispatches req through g.Client and parses the response.
+// It backs GenerateContext as well as Request.Get/GetContext/Iter
+// (via Request.getContext), so there's a single implementation of the
+// HTTP dispatch-and-parse logic rather than two that could drift apart.
+func (g *HTTPGenerator) generate(ctx context.Context, req *Request) ([]Response, error) {
+	rl := []Response{}
+	hr, err := g.Client.do(ctx, req.Request.WithContext(ctx))
+	if err != nil {
+		return rl, err
+	}
+	if hr.StatusCode != 200 {
+		return rl, unmarshalError(hr)
+	}
+	body, err := getResponseEntityBody(hr)
+	if err != nil {
+		return rl, err
+	}
+	if len(body) > 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &rl)
+		return rl, err
+	}
+	ri := Response{}
+	err = json.Unmarshal(body, &ri)
+	return append(rl, ri), err
+}
+
+//go:embed data/names.json
+var namesData embed.FS
+
+type regionNames struct {
+	Male     []string `json:"male"`
+	Female   []string `json:"female"`
+	Surnames []string `json:"surnames"`
+}
+
+var allRegions = mustLoadRegions()
+
+// allRegionNames is allRegions' keys in a fixed order.  LocalGenerator
+// picks a random region by indexing into this slice rather than
+// ranging over the map directly, since Go randomizes map iteration
+// order per-process; without it, the same seed could still produce a
+// different region on every run.
+var allRegionNames = sortedKeys(allRegions)
+
+func mustLoadRegions() map[string]regionNames {
+	data, err := namesData.ReadFile("data/names.json")
+	if err != nil {
+		panic("uinames: embedded name data is missing: " + err.Error())
+	}
+	var regions map[string]regionNames
+	if err := json.Unmarshal(data, &regions); err != nil {
+		panic("uinames: embedded name data is invalid: " + err.Error())
+	}
+	return regions
+}
+
+func sortedKeys(regions map[string]regionNames) []string {
+	names := make([]string, 0, len(regions))
+	for name := range regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unknownRegionErrorMsg is returned when Region names a region that
+// isn't present in the embedded dataset.
+const unknownRegionErrorMsg = "region not found in local name dataset"
+
+// LocalGenerator produces identities entirely offline, drawing names
+// from a dataset embedded in this module.  It honors the same request
+// options as the HTTP API (Amount, Gender, Region, MinimumLength,
+// MaximumLength and ExtraData), making it a drop-in replacement for
+// HTTPGenerator in tests or air-gapped environments.
+//
+// A LocalGenerator is safe for concurrent use only if its Rand is nil,
+// in which case each call draws its own source seeded from
+// crypto/rand; supplying a *math/rand.Rand trades that safety for
+// reproducible output.
+type LocalGenerator struct {
+	// Rand, if non-nil, is used as the source of randomness, making
+	// Generate's output reproducible across calls for the same seed.
+	// If nil, each call is seeded independently.
+	Rand *mathrand.Rand
+}
+
+// NewLocalGenerator creates a LocalGenerator seeded for reproducible
+// output, as needed in tests.
+func NewLocalGenerator(seed int64) *LocalGenerator {
+	return &LocalGenerator{Rand: mathrand.New(mathrand.NewSource(seed))}
+}
+
+// Generate implements Generator.
+func (g *LocalGenerator) Generate(opts ...Opt) ([]Response, error) {
+	return g.GenerateContext(context.Background(), opts...)
+}
+
+// GenerateContext implements Generator. ctx is not consulted, since
+// generation never blocks, but is accepted to satisfy Generator.
+func (g *LocalGenerator) GenerateContext(_ context.Context, opts ...Opt) ([]Response, error) {
+	v := url.Values{}
+	for _, opt := range opts {
+		if err := opt(&v); err != nil {
+			return nil, err
+		}
+	}
+
+	amount := 1
+	if a := v.Get(string(amountKey)); a != "" {
+		n, err := strconv.Atoi(a)
+		if err != nil {
+			return nil, err
+		}
+		amount = n
+	}
+	_, extra := v[string(extraDataKey)]
+	minLen, _ := strconv.Atoi(v.Get(string(minLenKey)))
+	maxLen, _ := strconv.Atoi(v.Get(string(maxLenKey)))
+
+	rnd := g.rand()
+	rl := make([]Response, 0, amount)
+	for i := 0; i < amount; i++ {
+		r, err := g.generateOne(rnd, v.Get(string(regionKey)), v.Get(string(genderKey)), minLen, maxLen, extra)
+		if err != nil {
+			return rl, err
+		}
+		rl = append(rl, r)
+	}
+	return rl, nil
+}
+
+func (g *LocalGenerator) rand() *mathrand.Rand {
+	if g.Rand != nil {
+		return g.Rand
+	}
+	seed, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	}
+	return mathrand.New(mathrand.NewSource(seed.Int64()))
+}
+
+func (g *LocalGenerator) generateOne(rnd *mathrand.Rand, regionName, genderName string, minLen, maxLen int, extra bool) (Response, error) {
+	if regionName == "" {
+		regionName = allRegionNames[rnd.Intn(len(allRegionNames))]
+	}
+	reg, ok := allRegions[regionName]
+	if !ok {
+		return Response{}, errors.New(unknownRegionErrorMsg)
+	}
+
+	if genderName == "" {
+		if rnd.Intn(2) == 0 {
+			genderName = string(Male)
+		} else {
+			genderName = string(Female)
+		}
+	}
+	names := reg.Male
+	if genderName == string(Female) {
+		names = reg.Female
+	}
+
+	name := pickWithLength(rnd, names, minLen, maxLen)
+	r := Response{
+		Name:    name,
+		Surname: pickWithLength(rnd, reg.Surnames, minLen, maxLen),
+		Gender:  genderName,
+		Region:  regionName,
+	}
+	if extra {
+		r.Age = 18 + rnd.Intn(63)
+		r.Title = title(genderName)
+		g.fillExtraData(rnd, &r)
+	}
+	return r, nil
+}
+
+// pickWithLength returns a random entry from names that satisfies
+// minLen/maxLen when possible, falling back to an unconstrained pick
+// if no entry matches (the dataset is small enough that this is
+// common for tight bounds).
+func pickWithLength(rnd *mathrand.Rand, names []string, minLen, maxLen int) string {
+	candidates := names
+	if minLen > 0 || maxLen > 0 {
+		filtered := make([]string, 0, len(names))
+		for _, n := range names {
+			if minLen > 0 && len(n) < minLen {
+				continue
+			}
+			if maxLen > 0 && len(n) > maxLen {
+				continue
+			}
+			filtered = append(filtered, n)
+		}
+		if len(filtered) > 0 {
+			candidates = filtered
+		}
+	}
+	return candidates[rnd.Intn(len(candidates))]
+}
+
+func title(genderName string) string {
+	if genderName == string(Female) {
+		return "Ms"
+	}
+	return "Mr"
+}
+
+func (g *LocalGenerator) fillExtraData(rnd *mathrand.Rand, r *Response) {
+	r.Email = fmt.Sprintf("%s.%s@example.com", lower(r.Name), lower(r.Surname))
+	r.Password = fmt.Sprintf("pw-%08d", rnd.Intn(1e8))
+	r.Phone = fmt.Sprintf("555-%03d-%04d", rnd.Intn(1000), rnd.Intn(10000))
+	birthYear := time.Now().Year() - r.Age
+	r.Birthdate = time.Date(birthYear, time.Month(1+rnd.Intn(12)), 1+rnd.Intn(28), 0, 0, 0, 0, time.UTC)
+	r.CreditCard = CreditCard{
+		Number:     fmt.Sprintf("4%03d%04d%04d%04d", rnd.Intn(1000), rnd.Intn(10000), rnd.Intn(10000), rnd.Intn(10000)),
+		Expiration: fmt.Sprintf("%02d/%02d", 1+rnd.Intn(12), (time.Now().Year()+1+rnd.Intn(5))%100),
+		Pin:        1000 + rnd.Intn(9000),
+		Security:   100 + rnd.Intn(900),
+	}
+	r.Photo, _ = url.Parse(fmt.Sprintf("https://example.com/photos/%s-%s.jpg", lower(r.Name), lower(r.Surname)))
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
+
+// FallbackGenerator wraps a primary and secondary Generator, falling
+// back to Secondary whenever Primary fails with a network error or an
+// Error carrying a 5xx status.  This lets callers keep using
+// HTTPGenerator by default while transparently degrading to a
+// LocalGenerator when uinames.com is unreachable, e.g. in air-gapped CI.
+type FallbackGenerator struct {
+	Primary   Generator
+	Secondary Generator
+}
+
+// Generate implements Generator.
+func (g FallbackGenerator) Generate(opts ...Opt) ([]Response, error) {
+	return g.GenerateContext(context.Background(), opts...)
+}
+
+// GenerateContext implements Generator.
+func (g FallbackGenerator) GenerateContext(ctx context.Context, opts ...Opt) ([]Response, error) {
+	rl, err := g.Primary.GenerateContext(ctx, opts...)
+	if isFallbackError(err) {
+		return g.Secondary.GenerateContext(ctx, opts...)
+	}
+	return rl, err
+}
+
+// isFallbackError reports whether err is the kind of failure
+// FallbackGenerator should recover from: a transport-level error
+// reaching uinames.com, or an Error carrying a 5xx status.  Anything
+// else - notably context.Canceled/context.DeadlineExceeded, which mean
+// the caller gave up rather than the primary failing - is passed
+// through unchanged.
+func isFallbackError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var uerr Error
+	if errors.As(err, &uerr) {
+		return uerr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
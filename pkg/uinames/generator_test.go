@@ -0,0 +1,134 @@
+package uinames
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalGeneratorIsReproducibleForASeed(t *testing.T) {
+	r1, err := NewLocalGenerator(42).Generate(Amount(5), ExtraData())
+	require.NoError(t, err)
+	r2, err := NewLocalGenerator(42).Generate(Amount(5), ExtraData())
+	require.NoError(t, err)
+
+	assert.Equal(t, r1, r2)
+}
+
+func TestLocalGeneratorHonorsAmount(t *testing.T) {
+	r, err := NewLocalGenerator(1).Generate(Amount(7))
+	require.NoError(t, err)
+	assert.Len(t, r, 7)
+}
+
+func TestLocalGeneratorHonorsGender(t *testing.T) {
+	r, err := NewLocalGenerator(1).Generate(Amount(10), Gender(Female))
+	require.NoError(t, err)
+	for _, ri := range r {
+		assert.Equal(t, string(Female), ri.Gender)
+	}
+}
+
+func TestLocalGeneratorHonorsRegion(t *testing.T) {
+	r, err := NewLocalGenerator(1).Generate(Amount(5), Region("Japan"))
+	require.NoError(t, err)
+	for _, ri := range r {
+		assert.Equal(t, "Japan", ri.Region)
+	}
+}
+
+func TestLocalGeneratorUnknownRegion(t *testing.T) {
+	_, err := NewLocalGenerator(1).Generate(Region("Atlantis"))
+	require.Error(t, err)
+	assert.Equal(t, unknownRegionErrorMsg, err.Error())
+}
+
+func TestLocalGeneratorHonorsLengthBounds(t *testing.T) {
+	r, err := NewLocalGenerator(1).Generate(Amount(20), MinimumLength(3), MaximumLength(10))
+	require.NoError(t, err)
+	for _, ri := range r {
+		assert.GreaterOrEqual(t, len(ri.Name), 3)
+		assert.LessOrEqual(t, len(ri.Name), 10)
+	}
+}
+
+func TestLocalGeneratorExtraData(t *testing.T) {
+	r, err := NewLocalGenerator(1).Generate(Amount(1), ExtraData())
+	require.NoError(t, err)
+	require.Len(t, r, 1)
+	ri := r[0]
+	assert.NotEmpty(t, ri.Email)
+	assert.NotEmpty(t, ri.Phone)
+	assert.NotZero(t, ri.Birthdate)
+	assert.NotEmpty(t, ri.CreditCard.Number)
+	assert.NotZero(t, ri.CreditCard.Pin)
+	require.NotNil(t, ri.Photo)
+}
+
+func TestLocalGeneratorWithoutExtraData(t *testing.T) {
+	r, err := NewLocalGenerator(1).Generate(Amount(1))
+	require.NoError(t, err)
+	require.Len(t, r, 1)
+	assert.Empty(t, r[0].Email)
+}
+
+type stubGenerator struct {
+	resp  []Response
+	err   error
+	calls int
+}
+
+func (s *stubGenerator) Generate(opts ...Opt) ([]Response, error) {
+	return s.GenerateContext(context.Background(), opts...)
+}
+
+func (s *stubGenerator) GenerateContext(ctx context.Context, opts ...Opt) ([]Response, error) {
+	s.calls++
+	return s.resp, s.err
+}
+
+func TestFallbackGeneratorFallsBackOn5xxError(t *testing.T) {
+	primary := &stubGenerator{err: Error{StatusCode: 503, Message: "unavailable"}}
+	secondary := &stubGenerator{resp: []Response{{Name: "Local"}}}
+	g := FallbackGenerator{Primary: primary, Secondary: secondary}
+
+	r, err := g.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, 1, secondary.calls)
+	assert.Equal(t, "Local", r[0].Name)
+}
+
+func TestFallbackGeneratorFallsBackOnNetworkError(t *testing.T) {
+	primary := &stubGenerator{err: &url.Error{Op: "Get", URL: URL, Err: errors.New("connection refused")}}
+	secondary := &stubGenerator{resp: []Response{{Name: "Local"}}}
+	g := FallbackGenerator{Primary: primary, Secondary: secondary}
+
+	r, err := g.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, 1, secondary.calls)
+	assert.Equal(t, "Local", r[0].Name)
+}
+
+func TestFallbackGeneratorDoesNotFallBackOn4xxError(t *testing.T) {
+	primary := &stubGenerator{err: Error{StatusCode: 400, Message: "bad request"}}
+	secondary := &stubGenerator{resp: []Response{{Name: "Local"}}}
+	g := FallbackGenerator{Primary: primary, Secondary: secondary}
+
+	_, err := g.Generate()
+	require.Error(t, err)
+	assert.Equal(t, 0, secondary.calls)
+}
+
+func TestFallbackGeneratorPropagatesContextCancellation(t *testing.T) {
+	primary := &stubGenerator{err: context.Canceled}
+	secondary := &stubGenerator{resp: []Response{{Name: "Local"}}}
+	g := FallbackGenerator{Primary: primary, Secondary: secondary}
+
+	_, err := g.GenerateContext(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, secondary.calls, "a cancelled context should not trigger fallback")
+}
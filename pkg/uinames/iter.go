@@ -0,0 +1,82 @@
+package uinames
+
+import "context"
+
+const maxAmount = 500
+
+// ResponseIter streams Response values from one or more requests to
+// the uinames API, transparently issuing additional requests (of up to
+// the API's 500-identity cap) as needed.  Obtain a ResponseIter via
+// Request.Iter.
+type ResponseIter struct {
+	ctx       context.Context
+	client    *Client
+	opts      []Opt
+	remaining int
+
+	buf []Response
+	cur Response
+	err error
+}
+
+// Iter returns a ResponseIter that will yield total Response values,
+// issuing as many requests of up to 500 identities each as required.
+// It reuses r's own options for every underlying request, except
+// Amount, which Iter manages itself; an explicit Amount option on r is
+// ignored. Requests are dispatched through the Client bound to r with
+// WithClient, or the package-level default Client if none was bound,
+// so they share its rate limit and retry policy.
+func (r *Request) Iter(ctx context.Context, total int) *ResponseIter {
+	return &ResponseIter{
+		ctx:       ctx,
+		client:    r.clientOrDefault(),
+		opts:      r.opts,
+		remaining: total,
+	}
+}
+
+// Next advances the iterator, fetching another batch of identities
+// from the uinames API if needed, and reports whether a Value is
+// available.  Next returns false once total identities (as passed to
+// Iter) have been produced, or when an error occurs; check Err to
+// distinguish the two.
+func (it *ResponseIter) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.remaining <= 0 {
+			return false
+		}
+		n := it.remaining
+		if n > maxAmount {
+			n = maxAmount
+		}
+		opts := append(append([]Opt{}, it.opts...), Amount(n))
+		req, err := NewRequest(opts...)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		resp, err := req.getContext(it.ctx, it.client)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.remaining -= n
+		it.buf = resp
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Value returns the Response produced by the most recent call to Next.
+func (it *ResponseIter) Value() Response {
+	return it.cur
+}
+
+// Err returns the first error encountered while fetching identities,
+// or nil if none occurred.
+func (it *ResponseIter) Err() error {
+	return it.err
+}
@@ -0,0 +1,104 @@
+package uinames
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawIdentityJSON(name string) string {
+	return `{"name":"` + name + `","surname":"Surname","gender":"male","region":"Testland",` +
+		`"age":30,"title":"Mr","phone":"555-0100",` +
+		`"birthday":{"dmy":"01/01/1990","mdy":"01/01/1990","raw":0},` +
+		`"email":"a@example.com","password":"pw",` +
+		`"credit_card":{"expiration":"01/30","number":"4111","pin":1234,"security":123},` +
+		`"photo":"https://example.com/photo.jpg"}`
+}
+
+// amountRoundTripper returns amount (from the request's "amount" query
+// parameter) synthetic identities as a JSON array, and counts how many
+// requests it served.
+func amountRoundTripper(t *testing.T) (http.RoundTripper, *int) {
+	t.Helper()
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if err := r.Context().Err(); err != nil {
+			return nil, err
+		}
+		amount, err := strconv.Atoi(r.URL.Query().Get("amount"))
+		require.NoError(t, err)
+		items := make([]string, amount)
+		for i := range items {
+			items[i] = rawIdentityJSON("Name")
+		}
+		return jsonResponse(http.StatusOK, "["+strings.Join(items, ",")+"]"), nil
+	})
+	return rt, &calls
+}
+
+func TestRequestIterChunksLargeAmounts(t *testing.T) {
+	rt, calls := amountRoundTripper(t)
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	req, err := NewRequest()
+	require.NoError(t, err)
+	req = req.WithClient(cl)
+
+	it := req.Iter(context.Background(), 1200)
+	count := 0
+	for it.Next() {
+		assert.NotEmpty(t, it.Value().Name)
+		count++
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, 1200, count)
+	assert.Equal(t, 3, *calls, "1200 identities should take 3 requests of <=500 each")
+}
+
+func TestRequestIterUsesBoundClient(t *testing.T) {
+	rt, _ := amountRoundTripper(t)
+	var hookCalls int
+	cl, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithHooks(func(*http.Request) { hookCalls++ }, nil),
+	)
+	require.NoError(t, err)
+
+	req, err := NewRequest()
+	require.NoError(t, err)
+	req = req.WithClient(cl)
+
+	it := req.Iter(context.Background(), 600)
+	for it.Next() {
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, 2, hookCalls, "Iter should dispatch through the Client bound via WithClient")
+}
+
+func TestRequestGetContextPropagatesCancellation(t *testing.T) {
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		if err := r.Context().Err(); err != nil {
+			return nil, err
+		}
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, err := NewRequest()
+	require.NoError(t, err)
+	req = req.WithClient(cl)
+
+	_, err = req.GetContext(ctx)
+	require.Error(t, err)
+}
@@ -3,5 +3,23 @@ Package uinames provides a client to retrieve fake identities from the
 uinames service (https://uinames.com).  In general, the process involves
 creating a new request with the selected options and then calling the
 Get() function (repeatedly if needed) to retrieve an array of identities.
+
+Requests are dispatched through a Client, which applies retry-with-backoff
+and, when configured with WithRateLimit, rate limiting against the
+uinames API.  A package-level default Client is used by Get() for
+backward compatibility; it retries according to DefaultRetryPolicy but
+applies no rate limit.  Construct one with NewClient to set rate limits,
+retries or request/response hooks.
+
+The Generator interface abstracts over how identities are produced.
+HTTPGenerator (the default, used internally by Get) talks to
+uinames.com; LocalGenerator produces identities entirely offline from a
+dataset embedded in this module; and FallbackGenerator combines the two
+so that callers keep working when uinames.com is unreachable.
+
+For bulk use, Request.Iter streams identities one at a time as a
+ResponseIter, and Pipe writes them through an Encoder (NDJSONEncoder,
+CSVEncoder or TemplateEncoder) without the caller having to marshal
+each Response by hand.
 */
 package uinames
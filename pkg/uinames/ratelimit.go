@@ -0,0 +1,214 @@
+package uinames
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RetryPolicy controls how a Client retries requests that fail with a
+// network error or a 5xx/429 response from the uinames API.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts (including the
+	// first), so MaxAttempts of 1 disables retrying.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry; subsequent
+	// retries back off exponentially from it.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff, before jitter is applied.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by Clients that don't configure a
+// RetryPolicy of their own: three attempts, starting at 250ms and
+// capping at 5s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// Client wraps an *http.Client with the rate limiting and retry
+// behaviour required by the uinames API, along with hooks for
+// observing outbound requests and their responses.  The zero value is
+// not ready to use; create one with NewClient.
+type Client struct {
+	http    *http.Client
+	limiter *rate.Limiter
+	retry   RetryPolicy
+
+	beforeHooks []func(*http.Request)
+	afterHooks  []func(*http.Response, error)
+}
+
+// ClientOpt is a function (normally accessed via a closure) that
+// configures a Client.
+type ClientOpt func(c *Client) error
+
+// WithRateLimit configures a Client to allow at most rps requests per
+// second, with bursts of up to burst requests.
+func WithRateLimit(rps float64, burst int) ClientOpt {
+	return func(c *Client) error {
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+		return nil
+	}
+}
+
+// WithRetry overrides a Client's RetryPolicy, which otherwise defaults
+// to DefaultRetryPolicy.
+func WithRetry(policy RetryPolicy) ClientOpt {
+	return func(c *Client) error {
+		c.retry = policy
+		return nil
+	}
+}
+
+// WithHTTPClient overrides the *http.Client a Client uses to dispatch
+// requests, which otherwise defaults to &http.Client{}.
+func WithHTTPClient(hc *http.Client) ClientOpt {
+	return func(c *Client) error {
+		c.http = hc
+		return nil
+	}
+}
+
+// WithHooks registers request/response hooks on a Client at
+// construction time.  Either argument may be nil.  Hooks can also be
+// added after construction via OnBeforeRequest and OnAfterResponse.
+func WithHooks(before func(*http.Request), after func(*http.Response, error)) ClientOpt {
+	return func(c *Client) error {
+		if before != nil {
+			c.beforeHooks = append(c.beforeHooks, before)
+		}
+		if after != nil {
+			c.afterHooks = append(c.afterHooks, after)
+		}
+		return nil
+	}
+}
+
+// NewClient creates a Client based on the included options.  With no
+// options, the Client has no rate limit and retries according to
+// DefaultRetryPolicy.
+func NewClient(opts ...ClientOpt) (*Client, error) {
+	c := &Client{
+		http:  &http.Client{},
+		retry: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+var defaultClient = mustDefaultClient()
+
+func mustDefaultClient() *Client {
+	c, err := NewClient()
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// OnBeforeRequest registers a hook that is called immediately before
+// every outbound HTTP request, including retries.
+func (c *Client) OnBeforeRequest(fn func(*http.Request)) {
+	c.beforeHooks = append(c.beforeHooks, fn)
+}
+
+// OnAfterResponse registers a hook that is called after every HTTP
+// response (or transport error) is received, including ones that go on
+// to be retried.
+func (c *Client) OnAfterResponse(fn func(*http.Response, error)) {
+	c.afterHooks = append(c.afterHooks, fn)
+}
+
+// Wait blocks until the Client's rate limiter allows another request,
+// or ctx is cancelled.  A Client with no rate limit configured never
+// blocks.
+func (c *Client) Wait(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.Wait(ctx)
+}
+
+// do dispatches hr, applying the Client's rate limit, hooks and retry
+// policy.  hr is reused across retries; since uinames requests never
+// carry a body, there's no body to rewind between attempts.
+func (c *Client) do(ctx context.Context, hr *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err := c.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		for _, hook := range c.beforeHooks {
+			hook(hr)
+		}
+		resp, err = c.http.Do(hr)
+		for _, hook := range c.afterHooks {
+			hook(resp, err)
+		}
+
+		if !c.shouldRetry(attempt, resp, err) {
+			return resp, err
+		}
+
+		// About to retry: drain and close the body so the underlying
+		// connection can be reused instead of leaked.
+		if resp != nil && resp.Body != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(c.retryDelay(attempt, resp))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) shouldRetry(attempt int, resp *http.Response, err error) bool {
+	if attempt >= c.retry.MaxAttempts-1 {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryDelay computes the delay before the next attempt: the
+// Retry-After header when present (either form allowed by RFC 7231 -
+// delay-seconds or an HTTP-date), otherwise exponential backoff from
+// BaseDelay (capped at MaxDelay) with up to 50% jitter.
+func (c *Client) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+			if when, err := http.ParseTime(ra); err == nil {
+				return time.Until(when)
+			}
+		}
+	}
+	delay := c.retry.BaseDelay * time.Duration(int64(1)<<uint(attempt))
+	if delay <= 0 || delay > c.retry.MaxDelay {
+		delay = c.retry.MaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2+1)))
+}
@@ -0,0 +1,226 @@
+package uinames
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type funcRoundTripper func(*http.Request) (*http.Response, error)
+
+func (f funcRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func newTestRequest(t *testing.T) *http.Request {
+	t.Helper()
+	hr, err := http.NewRequest("GET", URL, nil)
+	require.NoError(t, err)
+	return hr
+}
+
+func fastRetry(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+	}
+}
+
+func TestClientNoRetryOnClientError(t *testing.T) {
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(http.StatusBadRequest, `{"error":"nope"}`), nil
+	})
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	resp, err := cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientRetriesOn5xxThenSucceeds(t *testing.T) {
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls < 3 {
+			return jsonResponse(http.StatusServiceUnavailable, ""), nil
+		}
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetry(fastRetry(5)),
+	)
+	require.NoError(t, err)
+
+	resp, err := cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClientRetryExhaustsAttempts(t *testing.T) {
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(http.StatusServiceUnavailable, ""), nil
+	})
+	cl, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetry(fastRetry(3)),
+	)
+	require.NoError(t, err)
+
+	resp, err := cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClientHonorsRetryAfterHeader(t *testing.T) {
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := jsonResponse(http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "0")
+			return resp, nil
+		}
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	resp, err := cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClientHonorsRetryAfterHTTPDate(t *testing.T) {
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := jsonResponse(http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", time.Now().Add(time.Millisecond).UTC().Format(http.TimeFormat))
+			return resp, nil
+		}
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	resp, err := cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+// trackingBody wraps a reader and records whether Close was called, so
+// tests can confirm a retried response's body is drained and closed
+// rather than leaked.
+type trackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestClientDrainsAndClosesBodyBeforeRetrying(t *testing.T) {
+	firstBody := &trackingBody{Reader: strings.NewReader(`{"error":"retry me"}`)}
+	calls := 0
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Status:     http.StatusText(http.StatusServiceUnavailable),
+				Header:     http.Header{},
+				Body:       firstBody,
+			}, nil
+		}
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithRetry(fastRetry(2)),
+	)
+	require.NoError(t, err)
+
+	_, err = cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.True(t, firstBody.closed, "body of the retried response should have been drained and closed")
+}
+
+func TestClientHooksAreCalled(t *testing.T) {
+	var before, after int
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(
+		WithHTTPClient(&http.Client{Transport: rt}),
+		WithHooks(
+			func(*http.Request) { before++ },
+			func(*http.Response, error) { after++ },
+		),
+	)
+	require.NoError(t, err)
+
+	_, err = cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, 1, before)
+	assert.Equal(t, 1, after)
+}
+
+func TestClientOnBeforeRequestAndOnAfterResponse(t *testing.T) {
+	rt := funcRoundTripper(func(r *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, "[]"), nil
+	})
+	cl, err := NewClient(WithHTTPClient(&http.Client{Transport: rt}))
+	require.NoError(t, err)
+
+	var before, after int
+	cl.OnBeforeRequest(func(*http.Request) { before++ })
+	cl.OnAfterResponse(func(*http.Response, error) { after++ })
+
+	_, err = cl.do(context.Background(), newTestRequest(t))
+	require.NoError(t, err)
+	assert.Equal(t, 1, before)
+	assert.Equal(t, 1, after)
+}
+
+func TestClientWaitRespectsRateLimit(t *testing.T) {
+	cl, err := NewClient(WithRateLimit(1000, 1))
+	require.NoError(t, err)
+
+	require.NoError(t, cl.Wait(context.Background()))
+	start := time.Now()
+	require.NoError(t, cl.Wait(context.Background()))
+	elapsed := time.Since(start)
+
+	assert.GreaterOrEqual(t, elapsed, 500*time.Microsecond)
+}